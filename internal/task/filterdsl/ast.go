@@ -0,0 +1,65 @@
+package filterdsl
+
+import "codeberg.org/snonux/tasksamurai/internal/task"
+
+// Expr is a boolean predicate over a task, produced by Parse.
+type Expr interface {
+	Evaluate(t task.Task) bool
+}
+
+type andExpr struct{ left, right Expr }
+
+func (e andExpr) Evaluate(t task.Task) bool { return e.left.Evaluate(t) && e.right.Evaluate(t) }
+
+type orExpr struct{ left, right Expr }
+
+func (e orExpr) Evaluate(t task.Task) bool { return e.left.Evaluate(t) || e.right.Evaluate(t) }
+
+type notExpr struct{ inner Expr }
+
+func (e notExpr) Evaluate(t task.Task) bool { return !e.inner.Evaluate(t) }
+
+// op identifies a comparison operator in a cmpExpr.
+type op int
+
+const (
+	opEq op = iota
+	opNeq
+	opLt
+	opLte
+	opGt
+	opGte
+	opMatch
+	opNotMatch
+)
+
+// cmpExpr compares one task field against a literal value.
+type cmpExpr struct {
+	field string
+	op    op
+	value string
+}
+
+func (e cmpExpr) Evaluate(t task.Task) bool {
+	switch e.field {
+	case "project":
+		return compareString(t.Project, e.op, e.value)
+	case "priority":
+		return compareString(t.Priority, e.op, e.value)
+	case "tag", "tags":
+		return compareTags(t.Tags, e.op, e.value)
+	case "due":
+		return compareDate(t.Due, e.op, e.value)
+	case "scheduled":
+		return compareDate(t.Scheduled, e.op, e.value)
+	case "entry":
+		return compareDate(t.Entry, e.op, e.value)
+	case "urgency":
+		return compareFloat(t.Urgency, e.op, e.value)
+	case "desc", "description":
+		return compareString(t.Description, e.op, e.value)
+	case "status":
+		return compareString(t.Status, e.op, e.value)
+	}
+	return false
+}
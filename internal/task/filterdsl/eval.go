@@ -0,0 +1,151 @@
+package filterdsl
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// regexCache caches compiled "~" patterns for the lifetime of the process,
+// the same way the UI layer caches its own search regexes.
+var regexCache = make(map[string]*regexp.Regexp)
+
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	if re, ok := regexCache[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	regexCache[pattern] = re
+	return re, nil
+}
+
+func compareString(actual string, o op, value string) bool {
+	switch o {
+	case opEq:
+		return actual == value
+	case opNeq:
+		return actual != value
+	case opMatch, opNotMatch:
+		re, err := compileRegex(value)
+		if err != nil {
+			return false
+		}
+		matched := re.MatchString(actual)
+		if o == opNotMatch {
+			return !matched
+		}
+		return matched
+	case opLt:
+		return actual < value
+	case opLte:
+		return actual <= value
+	case opGt:
+		return actual > value
+	case opGte:
+		return actual >= value
+	}
+	return false
+}
+
+// compareTags applies o to a task's tag set. Equality/inequality test
+// membership; match/not-match and the ordering operators fall back to
+// compareString against each tag in turn, so e.g. tag~/^wip/ matches if any
+// tag matches the regex and tag>m sorts lexicographically by tag name.
+func compareTags(tags []string, o op, value string) bool {
+	switch o {
+	case opEq:
+		return tagsContain(tags, value)
+	case opNeq:
+		return !tagsContain(tags, value)
+	}
+	for _, t := range tags {
+		if compareString(t, o, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func tagsContain(tags []string, value string) bool {
+	for _, t := range tags {
+		if t == value {
+			return true
+		}
+	}
+	return false
+}
+
+func compareFloat(actual float64, o op, value string) bool {
+	want, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return false
+	}
+	switch o {
+	case opEq:
+		return actual == want
+	case opNeq:
+		return actual != want
+	case opLt:
+		return actual < want
+	case opLte:
+		return actual <= want
+	case opGt:
+		return actual > want
+	case opGte:
+		return actual >= want
+	}
+	return false
+}
+
+// compareDate resolves both sides through time.Time before comparing: the
+// task's raw date field is parsed via parseTaskDate, and the RHS is parsed
+// via the natural-language task.ParseOffset so expressions like "due<+3d"
+// work directly in the DSL.
+func compareDate(actual string, o op, value string) bool {
+	actualTime, err := parseTaskDate(actual)
+	if err != nil {
+		return false
+	}
+	wantTime, err := task.ParseOffset(time.Now(), value)
+	if err != nil {
+		return false
+	}
+	switch o {
+	case opEq:
+		return actualTime.Equal(wantTime)
+	case opNeq:
+		return !actualTime.Equal(wantTime)
+	case opLt:
+		return actualTime.Before(wantTime)
+	case opLte:
+		return !actualTime.After(wantTime)
+	case opGt:
+		return actualTime.After(wantTime)
+	case opGte:
+		return !actualTime.Before(wantTime)
+	}
+	return false
+}
+
+// parseTaskDate parses taskwarrior's compact UTC timestamp format, falling
+// back to a plain calendar date.
+func parseTaskDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty date")
+	}
+	if t, err := time.Parse("20060102T150405Z", s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("unrecognized date format: %q", s)
+}
@@ -0,0 +1,116 @@
+package filterdsl
+
+import (
+	"testing"
+	"time"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func mustParse(t *testing.T, s string) Expr {
+	t.Helper()
+	expr, err := Parse(s)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", s, err)
+	}
+	return expr
+}
+
+func TestParseAndEvaluateSimpleField(t *testing.T) {
+	expr := mustParse(t, "project:work")
+	if !expr.Evaluate(task.Task{Project: "work"}) {
+		t.Error("expected project:work to match a task in project work")
+	}
+	if expr.Evaluate(task.Task{Project: "home"}) {
+		t.Error("expected project:work not to match a task in project home")
+	}
+}
+
+func TestParseImplicitAnd(t *testing.T) {
+	expr := mustParse(t, "project:work -tag:blocked")
+	matching := task.Task{Project: "work", Tags: []string{"urgent"}}
+	blocked := task.Task{Project: "work", Tags: []string{"blocked"}}
+	if !expr.Evaluate(matching) {
+		t.Error("expected implicit AND to match an unblocked work task")
+	}
+	if expr.Evaluate(blocked) {
+		t.Error("expected implicit AND to exclude a blocked work task")
+	}
+}
+
+func TestParseOrAndParens(t *testing.T) {
+	expr := mustParse(t, "project:work AND (priority:H OR priority:M)")
+	if !expr.Evaluate(task.Task{Project: "work", Priority: "H"}) {
+		t.Error("expected project:work AND (priority:H OR priority:M) to match priority H")
+	}
+	if !expr.Evaluate(task.Task{Project: "work", Priority: "M"}) {
+		t.Error("expected project:work AND (priority:H OR priority:M) to match priority M")
+	}
+	if expr.Evaluate(task.Task{Project: "work", Priority: "L"}) {
+		t.Error("expected project:work AND (priority:H OR priority:M) not to match priority L")
+	}
+}
+
+func TestParseRegexLiteral(t *testing.T) {
+	expr := mustParse(t, "desc~/^review/i")
+	if !expr.Evaluate(task.Task{Description: "Review the PR"}) {
+		t.Error("expected desc~/^review/i to match a case-insensitive prefix")
+	}
+	if expr.Evaluate(task.Task{Description: "Ask for a review"}) {
+		t.Error("expected desc~/^review/i not to match mid-string")
+	}
+}
+
+func TestParseTagOperators(t *testing.T) {
+	cases := []struct {
+		expr  string
+		tags  []string
+		match bool
+	}{
+		{"tag:wip", []string{"wip", "urgent"}, true},
+		{"tag:wip", []string{"urgent"}, false},
+		{"tag!=wip", []string{"urgent"}, true},
+		{"tag~/^wi/", []string{"wip"}, true},
+		{"tag~/^wi/", []string{"urgent"}, false},
+		{"tag!~/^wi/", []string{"urgent"}, true},
+	}
+	for _, c := range cases {
+		expr := mustParse(t, c.expr)
+		got := expr.Evaluate(task.Task{Tags: c.tags})
+		if got != c.match {
+			t.Errorf("Parse(%q).Evaluate(tags=%v) = %v, want %v", c.expr, c.tags, got, c.match)
+		}
+	}
+}
+
+func TestParseUrgencyComparison(t *testing.T) {
+	expr := mustParse(t, "urgency>5")
+	if !expr.Evaluate(task.Task{Urgency: 9.5}) {
+		t.Error("expected urgency>5 to match urgency 9.5")
+	}
+	if expr.Evaluate(task.Task{Urgency: 1}) {
+		t.Error("expected urgency>5 not to match urgency 1")
+	}
+}
+
+func TestParseNegativeDurationValue(t *testing.T) {
+	expr := mustParse(t, "due<-3d")
+	now := time.Now()
+	overdue := task.Task{Due: now.AddDate(0, 0, -10).Format("20060102T150405Z")}
+	future := task.Task{Due: now.AddDate(0, 0, 10).Format("20060102T150405Z")}
+	if !expr.Evaluate(overdue) {
+		t.Error("expected due<-3d to match a task overdue by 10 days")
+	}
+	if expr.Evaluate(future) {
+		t.Error("expected due<-3d not to match a task due 10 days from now")
+	}
+}
+
+func TestParseInvalidSyntax(t *testing.T) {
+	if _, err := Parse("project:work AND"); err == nil {
+		t.Fatal("expected an error for a dangling AND")
+	}
+	if _, err := Parse("project:work)"); err == nil {
+		t.Fatal("expected an error for an unmatched paren")
+	}
+}
@@ -0,0 +1,145 @@
+package filterdsl
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokRegex
+	tokLParen
+	tokRParen
+	tokAnd
+	tokOr
+	tokNot
+	tokOp
+	tokMinus
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// lexer tokenizes a filter expression such as
+// `project:work AND (priority:H OR due<+3d) AND -tag:blocked NOT desc~/review/i`.
+type lexer struct {
+	input []rune
+	pos   int
+}
+
+func newLexer(s string) *lexer {
+	return &lexer{input: []rune(s)}
+}
+
+// operators, ordered so multi-character operators are matched before their
+// single-character prefixes.
+var operators = []string{"!~", "!=", "<=", ">=", "~", "<", ">", "=", ":"}
+
+func (l *lexer) next() (token, error) {
+	l.skipSpace()
+	if l.pos >= len(l.input) {
+		return token{kind: tokEOF}, nil
+	}
+
+	switch l.input[l.pos] {
+	case '(':
+		l.pos++
+		return token{kind: tokLParen, text: "("}, nil
+	case ')':
+		l.pos++
+		return token{kind: tokRParen, text: ")"}, nil
+	case '-':
+		l.pos++
+		return token{kind: tokMinus, text: "-"}, nil
+	case '/':
+		return l.readRegex()
+	}
+
+	if op, ok := l.readOperator(); ok {
+		return token{kind: tokOp, text: op}, nil
+	}
+
+	return l.readWord()
+}
+
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.input) && unicode.IsSpace(l.input[l.pos]) {
+		l.pos++
+	}
+}
+
+func (l *lexer) readOperator() (string, bool) {
+	rest := string(l.input[l.pos:])
+	for _, op := range operators {
+		if strings.HasPrefix(rest, op) {
+			l.pos += len([]rune(op))
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// readRegex reads a /pattern/flags literal, translating flags into a Go
+// regexp inline flag group (e.g. /review/i -> (?i)review).
+func (l *lexer) readRegex() (token, error) {
+	start := l.pos
+	l.pos++ // opening '/'
+	var sb strings.Builder
+	for l.pos < len(l.input) && l.input[l.pos] != '/' {
+		sb.WriteRune(l.input[l.pos])
+		l.pos++
+	}
+	if l.pos >= len(l.input) {
+		return token{}, fmt.Errorf("unterminated regex starting at %d", start)
+	}
+	l.pos++ // closing '/'
+
+	var flags strings.Builder
+	for l.pos < len(l.input) && unicode.IsLetter(l.input[l.pos]) {
+		flags.WriteRune(l.input[l.pos])
+		l.pos++
+	}
+
+	pattern := sb.String()
+	if flags.Len() > 0 {
+		pattern = fmt.Sprintf("(?%s)%s", flags.String(), pattern)
+	}
+	return token{kind: tokRegex, text: pattern}, nil
+}
+
+// wordBreakChars are characters that end a bare word/identifier token.
+const wordBreakChars = "()<>=~!:"
+
+func (l *lexer) readWord() (token, error) {
+	start := l.pos
+	for l.pos < len(l.input) {
+		r := l.input[l.pos]
+		if unicode.IsSpace(r) || strings.ContainsRune(wordBreakChars, r) {
+			break
+		}
+		l.pos++
+	}
+	if l.pos == start {
+		return token{}, fmt.Errorf("unexpected character %q at %d", l.input[l.pos], l.pos)
+	}
+	word := string(l.input[start:l.pos])
+
+	switch strings.ToUpper(word) {
+	case "AND":
+		return token{kind: tokAnd, text: word}, nil
+	case "OR":
+		return token{kind: tokOr, text: word}, nil
+	case "NOT":
+		return token{kind: tokNot, text: word}, nil
+	}
+	if len(word) >= 2 && strings.HasPrefix(word, `"`) && strings.HasSuffix(word, `"`) {
+		word = strings.Trim(word, `"`)
+	}
+	return token{kind: tokIdent, text: word}, nil
+}
@@ -0,0 +1,186 @@
+package filterdsl
+
+import "fmt"
+
+// Parse compiles a filter expression such as
+// `project:work AND (priority:H OR due<+3d) AND -tag:blocked NOT desc~/review/i`
+// into an Expr that can be evaluated directly against tasks, without
+// shelling out to taskwarrior.
+func Parse(s string) (Expr, error) {
+	p := &parser{lex: newLexer(s)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected token %q", p.tok.text)
+	}
+	return expr, nil
+}
+
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokOr {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokAnd || p.startsUnary() {
+		if p.tok.kind == tokAnd {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+// startsUnary reports whether the current token can begin another operand,
+// allowing an implicit AND between adjacent predicates (e.g.
+// "project:work -tag:blocked").
+func (p *parser) startsUnary() bool {
+	switch p.tok.kind {
+	case tokIdent, tokMinus, tokNot, tokLParen:
+		return true
+	}
+	return false
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	switch p.tok.kind {
+	case tokNot, tokMinus:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{inner: inner}, nil
+	case tokLParen:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokRParen {
+			return nil, fmt.Errorf("expected ')'")
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	case tokIdent:
+		return p.parseCmp()
+	}
+	return nil, fmt.Errorf("unexpected token %q", p.tok.text)
+}
+
+func (p *parser) parseCmp() (Expr, error) {
+	field := p.tok.text
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind != tokOp {
+		return nil, fmt.Errorf("expected operator after field %q", field)
+	}
+	operator, err := parseOp(p.tok.text)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	var value string
+	switch p.tok.kind {
+	case tokIdent:
+		value = p.tok.text
+	case tokRegex:
+		value = p.tok.text
+		if operator == opEq {
+			operator = opMatch
+		}
+	case tokMinus:
+		// A '-' right after an operator is the sign of a negative offset
+		// (e.g. "due<-3d"), not the unary-NOT shorthand, which only applies
+		// at the start of an operand.
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokIdent {
+			return nil, fmt.Errorf("expected value after '-' for field %q", field)
+		}
+		value = "-" + p.tok.text
+	default:
+		return nil, fmt.Errorf("expected value after operator for field %q", field)
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	return cmpExpr{field: field, op: operator, value: value}, nil
+}
+
+func parseOp(s string) (op, error) {
+	switch s {
+	case ":", "=":
+		return opEq, nil
+	case "!=":
+		return opNeq, nil
+	case "<":
+		return opLt, nil
+	case "<=":
+		return opLte, nil
+	case ">":
+		return opGt, nil
+	case ">=":
+		return opGte, nil
+	case "~":
+		return opMatch, nil
+	case "!~":
+		return opNotMatch, nil
+	}
+	return 0, fmt.Errorf("unsupported operator %q", s)
+}
@@ -0,0 +1,170 @@
+// Package history implements a bounded, disk-persisted undo/redo stack for
+// taskwarrior mutations issued by the UI, so that 'u'/Ctrl-R can roll a
+// change back (or forward) across process restarts.
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Op is a single undoable mutation: the task ID, the field that changed
+// (e.g. "description", "tags", "due", "project", "priority", "recurrence",
+// "annotation", "add"), and the value immediately before and after the call
+// so either direction can be replayed without re-deriving it from taskwarrior.
+type Op struct {
+	ID       int       `json:"id"`
+	Action   string    `json:"action"`
+	OldValue string    `json:"oldValue"`
+	NewValue string    `json:"newValue"`
+	At       time.Time `json:"at"`
+}
+
+// maxOps bounds the ring buffer so history.json can't grow unboundedly over
+// a long-running session.
+const maxOps = 200
+
+// Stack is a bounded undo/redo stack persisted to disk after every mutation.
+// Push clears the redo side, matching standard editor undo semantics: a
+// fresh edit invalidates any previously undone redo history.
+type Stack struct {
+	mu   sync.Mutex
+	path string
+	ops  []Op
+	redo []Op
+}
+
+// Open loads the history stack from $XDG_STATE_HOME/tasksamurai/history.json
+// (or ~/.local/state/tasksamurai/history.json when XDG_STATE_HOME is unset),
+// returning an empty stack if the file doesn't exist yet.
+func Open() (*Stack, error) {
+	path, err := statePath()
+	if err != nil {
+		return nil, err
+	}
+	s := &Stack{path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+	var saved struct {
+		Ops  []Op `json:"ops"`
+		Redo []Op `json:"redo"`
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	s.ops = saved.Ops
+	s.redo = saved.Redo
+	return s, nil
+}
+
+func statePath() (string, error) {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "tasksamurai", "history.json"), nil
+}
+
+// Push records a completed mutation and persists the stack.
+func (s *Stack) Push(op Op) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ops = append(s.ops, op)
+	if len(s.ops) > maxOps {
+		s.ops = s.ops[len(s.ops)-maxOps:]
+	}
+	s.redo = nil
+	return s.save()
+}
+
+// Undo pops the most recent op onto the redo stack and returns it so the
+// caller can replay its inverse (OldValue) against taskwarrior.
+func (s *Stack) Undo() (Op, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.ops) == 0 {
+		return Op{}, false
+	}
+	op := s.ops[len(s.ops)-1]
+	s.ops = s.ops[:len(s.ops)-1]
+	s.redo = append(s.redo, op)
+	_ = s.save()
+	return op, true
+}
+
+// Redo pops the most recently undone op back onto the undo stack and
+// returns it so the caller can re-apply it (NewValue) against taskwarrior.
+func (s *Stack) Redo() (Op, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.redo) == 0 {
+		return Op{}, false
+	}
+	op := s.redo[len(s.redo)-1]
+	s.redo = s.redo[:len(s.redo)-1]
+	s.ops = append(s.ops, op)
+	_ = s.save()
+	return op, true
+}
+
+// ReplaceTop overwrites the op on top of the undo stack (the one most recently
+// pushed or redone) and persists the stack. Used when redoing an "add" discovers the
+// task's real ID only after taskwarrior re-creates it under a new one, so a
+// later Undo doesn't act on the stale ID the op was first pushed with.
+func (s *Stack) ReplaceTop(op Op) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.ops) == 0 {
+		return fmt.Errorf("history: no op to replace")
+	}
+	s.ops[len(s.ops)-1] = op
+	return s.save()
+}
+
+// Recent returns up to n of the most recently pushed ops, newest first, for
+// the :history view.
+func (s *Stack) Recent(n int) []Op {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n > len(s.ops) {
+		n = len(s.ops)
+	}
+	out := make([]Op, n)
+	for i := 0; i < n; i++ {
+		out[i] = s.ops[len(s.ops)-1-i]
+	}
+	return out
+}
+
+func (s *Stack) save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(struct {
+		Ops  []Op `json:"ops"`
+		Redo []Op `json:"redo"`
+	}{s.ops, s.redo}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
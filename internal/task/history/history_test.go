@@ -0,0 +1,125 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func openTestStack(t *testing.T) *Stack {
+	t.Helper()
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	s, err := Open()
+	if err != nil {
+		t.Fatalf("Open(): %v", err)
+	}
+	return s
+}
+
+func TestPushUndoRedo(t *testing.T) {
+	s := openTestStack(t)
+	op := Op{ID: 1, Action: "description", OldValue: "old", NewValue: "new", At: time.Now()}
+	if err := s.Push(op); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+
+	got, ok := s.Undo()
+	if !ok {
+		t.Fatal("expected Undo to return the pushed op")
+	}
+	if got != op {
+		t.Errorf("Undo() = %+v, want %+v", got, op)
+	}
+	if _, ok := s.Undo(); ok {
+		t.Fatal("expected a second Undo with nothing left to return false")
+	}
+
+	got, ok = s.Redo()
+	if !ok {
+		t.Fatal("expected Redo to return the undone op")
+	}
+	if got != op {
+		t.Errorf("Redo() = %+v, want %+v", got, op)
+	}
+	if _, ok := s.Redo(); ok {
+		t.Fatal("expected a second Redo with nothing left to return false")
+	}
+}
+
+func TestPushClearsRedo(t *testing.T) {
+	s := openTestStack(t)
+	first := Op{ID: 1, Action: "description", OldValue: "a", NewValue: "b", At: time.Now()}
+	second := Op{ID: 2, Action: "project", OldValue: "x", NewValue: "y", At: time.Now()}
+
+	if err := s.Push(first); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	if _, ok := s.Undo(); !ok {
+		t.Fatal("expected Undo to succeed")
+	}
+	if err := s.Push(second); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	if _, ok := s.Redo(); ok {
+		t.Fatal("expected a fresh Push to clear the redo stack")
+	}
+}
+
+func TestBoundedRingBuffer(t *testing.T) {
+	s := openTestStack(t)
+	for i := 0; i < maxOps+10; i++ {
+		if err := s.Push(Op{ID: i, Action: "description", At: time.Now()}); err != nil {
+			t.Fatalf("Push(%d): %v", i, err)
+		}
+	}
+	if len(s.ops) != maxOps {
+		t.Fatalf("len(s.ops) = %d, want %d", len(s.ops), maxOps)
+	}
+	if s.ops[len(s.ops)-1].ID != maxOps+9 {
+		t.Errorf("newest op ID = %d, want %d", s.ops[len(s.ops)-1].ID, maxOps+9)
+	}
+}
+
+func TestRecentNewestFirst(t *testing.T) {
+	s := openTestStack(t)
+	for i := 1; i <= 3; i++ {
+		if err := s.Push(Op{ID: i, Action: "description", At: time.Now()}); err != nil {
+			t.Fatalf("Push(%d): %v", i, err)
+		}
+	}
+	recent := s.Recent(2)
+	if len(recent) != 2 {
+		t.Fatalf("len(Recent(2)) = %d, want 2", len(recent))
+	}
+	if recent[0].ID != 3 || recent[1].ID != 2 {
+		t.Errorf("Recent(2) = %+v, want IDs [3, 2]", recent)
+	}
+}
+
+func TestReplaceTop(t *testing.T) {
+	s := openTestStack(t)
+	op := Op{ID: 1, Action: "add", OldValue: "", NewValue: "task one", At: time.Now()}
+	if err := s.Push(op); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+
+	corrected := op
+	corrected.ID = 42
+	if err := s.ReplaceTop(corrected); err != nil {
+		t.Fatalf("ReplaceTop: %v", err)
+	}
+
+	got, ok := s.Undo()
+	if !ok {
+		t.Fatal("expected Undo to return the corrected op")
+	}
+	if got.ID != 42 {
+		t.Errorf("Undo() after ReplaceTop returned ID %d, want 42", got.ID)
+	}
+}
+
+func TestReplaceTopEmptyStack(t *testing.T) {
+	s := openTestStack(t)
+	if err := s.ReplaceTop(Op{ID: 1}); err == nil {
+		t.Fatal("expected ReplaceTop on an empty stack to return an error")
+	}
+}
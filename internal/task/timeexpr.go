@@ -0,0 +1,234 @@
+package task
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseOffset parses a natural-language or taskwarrior-style time expression
+// relative to now into an absolute time. It understands signed durations
+// ("+3h", "-1d", "in 2 weeks"), relative days ("today", "tomorrow",
+// "yesterday"), weekday names with an optional next/last qualifier ("next
+// fri", "last monday"), an optional trailing "HH:MM" clock time ("yesterday
+// 17:20"), and taskwarrior's named anchors (sod, eod, sow, eow, som, eom).
+func ParseOffset(now time.Time, s string) (time.Time, error) {
+	s = strings.TrimSpace(strings.ToLower(s))
+	if s == "" {
+		return time.Time{}, fmt.Errorf("empty time expression")
+	}
+
+	base, clock := splitTrailingClock(s)
+
+	t, err := parseBase(now, base)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if clock != "" {
+		hour, minute, err := parseClock(clock)
+		if err != nil {
+			return time.Time{}, err
+		}
+		t = time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, t.Location())
+	}
+	return t, nil
+}
+
+// splitTrailingClock splits a trailing "HH:MM" token off s, e.g. turning
+// "yesterday 17:20" into ("yesterday", "17:20").
+func splitTrailingClock(s string) (base, clock string) {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return s, ""
+	}
+	last := fields[len(fields)-1]
+	if _, _, err := parseClock(last); err != nil {
+		return s, ""
+	}
+	return strings.Join(fields[:len(fields)-1], " "), last
+}
+
+func parseClock(s string) (hour, minute int, err error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("not a clock time: %q", s)
+	}
+	hour, err = strconv.Atoi(parts[0])
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, 0, fmt.Errorf("invalid hour in %q", s)
+	}
+	minute, err = strconv.Atoi(parts[1])
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid minute in %q", s)
+	}
+	return hour, minute, nil
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var namedAnchors = map[string]func(time.Time) time.Time{
+	"sod": startOfDay,
+	"eod": endOfDay,
+	"sow": startOfWeek,
+	"eow": endOfWeek,
+	"som": startOfMonth,
+	"eom": endOfMonth,
+}
+
+// signedOffsetRe matches taskwarrior-style signed durations like "-1d",
+// "+3h" or "15m".
+var signedOffsetRe = regexp.MustCompile(`^([+-]?\d+)\s*(mo|[mhdwy])$`)
+
+func parseBase(now time.Time, s string) (time.Time, error) {
+	switch s {
+	case "today", "now":
+		return now, nil
+	case "tomorrow":
+		return now.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return now.AddDate(0, 0, -1), nil
+	}
+
+	if anchor, ok := namedAnchors[s]; ok {
+		return anchor(now), nil
+	}
+
+	if m := signedOffsetRe.FindStringSubmatch(s); m != nil {
+		return applyOffset(now, m[1], m[2])
+	}
+
+	if rest, ok := strings.CutPrefix(s, "in "); ok {
+		return parseInExpr(now, rest)
+	}
+
+	if fields := strings.Fields(s); len(fields) >= 1 {
+		qualifier := ""
+		name := fields[0]
+		if len(fields) == 2 && (fields[0] == "next" || fields[0] == "last") {
+			qualifier = fields[0]
+			name = fields[1]
+		}
+		if wd, ok := weekdayNames[name]; ok {
+			return nextWeekday(now, wd, qualifier), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognized time expression: %q", s)
+}
+
+// parseInExpr parses "in <n> <unit>" expressions such as "in 2 weeks".
+func parseInExpr(now time.Time, rest string) (time.Time, error) {
+	fields := strings.Fields(rest)
+	if len(fields) != 2 {
+		return time.Time{}, fmt.Errorf("unrecognized duration: %q", rest)
+	}
+	count, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid count in %q: %w", rest, err)
+	}
+	unit, err := normalizeUnit(fields[1])
+	if err != nil {
+		return time.Time{}, err
+	}
+	return applyOffset(now, strconv.Itoa(count), unit)
+}
+
+func normalizeUnit(word string) (string, error) {
+	word = strings.TrimSuffix(word, "s")
+	switch word {
+	case "minute", "min", "m":
+		return "m", nil
+	case "hour", "hr", "h":
+		return "h", nil
+	case "day", "d":
+		return "d", nil
+	case "week", "wk", "w":
+		return "w", nil
+	case "month", "mo":
+		return "mo", nil
+	case "year", "yr", "y":
+		return "y", nil
+	}
+	return "", fmt.Errorf("unrecognized time unit: %q", word)
+}
+
+func applyOffset(now time.Time, countStr, unit string) (time.Time, error) {
+	count, err := strconv.Atoi(countStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid offset count: %q", countStr)
+	}
+	switch unit {
+	case "m":
+		return now.Add(time.Duration(count) * time.Minute), nil
+	case "h":
+		return now.Add(time.Duration(count) * time.Hour), nil
+	case "d":
+		return now.AddDate(0, 0, count), nil
+	case "w":
+		return now.AddDate(0, 0, count*7), nil
+	case "mo":
+		return now.AddDate(0, count, 0), nil
+	case "y":
+		return now.AddDate(count, 0, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time unit: %q", unit)
+}
+
+// nextWeekday resolves a bare, "next"-qualified or "last"-qualified weekday
+// name relative to now. A bare name always resolves to an upcoming occurrence
+// (today doesn't count), "next" skips one further week, and "last" resolves
+// to the most recent past occurrence.
+func nextWeekday(now time.Time, target time.Weekday, qualifier string) time.Time {
+	delta := int(target - now.Weekday())
+	switch qualifier {
+	case "next":
+		if delta <= 0 {
+			delta += 7
+		}
+		delta += 7
+	case "last":
+		if delta >= 0 {
+			delta -= 7
+		}
+	default:
+		if delta <= 0 {
+			delta += 7
+		}
+	}
+	return now.AddDate(0, 0, delta)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func endOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 0, t.Location())
+}
+
+func startOfWeek(t time.Time) time.Time {
+	d := startOfDay(t)
+	return d.AddDate(0, 0, -int(d.Weekday()))
+}
+
+func endOfWeek(t time.Time) time.Time {
+	return endOfDay(startOfWeek(t).AddDate(0, 0, 6))
+}
+
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+func endOfMonth(t time.Time) time.Time {
+	return endOfDay(startOfMonth(t).AddDate(0, 1, 0).Add(-24 * time.Hour))
+}
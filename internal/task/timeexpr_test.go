@@ -0,0 +1,108 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+var refNow = time.Date(2026, time.July, 29, 12, 0, 0, 0, time.UTC) // a Wednesday
+
+func TestParseOffsetRelativeDays(t *testing.T) {
+	cases := map[string]time.Time{
+		"today":     refNow,
+		"tomorrow":  refNow.AddDate(0, 0, 1),
+		"yesterday": refNow.AddDate(0, 0, -1),
+	}
+	for expr, want := range cases {
+		got, err := ParseOffset(refNow, expr)
+		if err != nil {
+			t.Fatalf("ParseOffset(%q): %v", expr, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseOffset(%q) = %v, want %v", expr, got, want)
+		}
+	}
+}
+
+func TestParseOffsetSignedDuration(t *testing.T) {
+	got, err := ParseOffset(refNow, "+3d")
+	if err != nil {
+		t.Fatalf("ParseOffset(+3d): %v", err)
+	}
+	if want := refNow.AddDate(0, 0, 3); !got.Equal(want) {
+		t.Errorf("ParseOffset(+3d) = %v, want %v", got, want)
+	}
+}
+
+func TestParseOffsetInExpr(t *testing.T) {
+	got, err := ParseOffset(refNow, "in 2 weeks")
+	if err != nil {
+		t.Fatalf("ParseOffset(in 2 weeks): %v", err)
+	}
+	if want := refNow.AddDate(0, 0, 14); !got.Equal(want) {
+		t.Errorf("ParseOffset(in 2 weeks) = %v, want %v", got, want)
+	}
+}
+
+func TestParseOffsetWeekday(t *testing.T) {
+	got, err := ParseOffset(refNow, "next fri")
+	if err != nil {
+		t.Fatalf("ParseOffset(next fri): %v", err)
+	}
+	if got.Weekday() != time.Friday {
+		t.Errorf("ParseOffset(next fri) landed on %v, want Friday", got.Weekday())
+	}
+	if !got.After(refNow.AddDate(0, 0, 7)) {
+		t.Errorf("ParseOffset(next fri) = %v, want more than a week out from %v", got, refNow)
+	}
+}
+
+func TestParseOffsetTrailingClock(t *testing.T) {
+	got, err := ParseOffset(refNow, "tomorrow 17:20")
+	if err != nil {
+		t.Fatalf("ParseOffset(tomorrow 17:20): %v", err)
+	}
+	if got.Hour() != 17 || got.Minute() != 20 {
+		t.Errorf("ParseOffset(tomorrow 17:20) = %v, want 17:20", got)
+	}
+}
+
+func TestParseOffsetNamedAnchors(t *testing.T) {
+	cases := []struct {
+		expr                 string
+		wantHour, wantMinute int
+		wantWeekday          time.Weekday
+		checkWeekday         bool
+		wantLastDayOfMonth   bool
+	}{
+		{expr: "sod", wantHour: 0, wantMinute: 0},
+		{expr: "eod", wantHour: 23, wantMinute: 59},
+		{expr: "sow", wantHour: 0, wantMinute: 0, wantWeekday: time.Sunday, checkWeekday: true},
+		{expr: "eow", wantHour: 23, wantMinute: 59, wantWeekday: time.Saturday, checkWeekday: true},
+		{expr: "som", wantHour: 0, wantMinute: 0},
+		{expr: "eom", wantHour: 23, wantMinute: 59, wantLastDayOfMonth: true},
+	}
+	for _, c := range cases {
+		got, err := ParseOffset(refNow, c.expr)
+		if err != nil {
+			t.Fatalf("ParseOffset(%q): %v", c.expr, err)
+		}
+		if got.Hour() != c.wantHour || got.Minute() != c.wantMinute {
+			t.Errorf("ParseOffset(%q) = %v, want hour:minute %02d:%02d", c.expr, got, c.wantHour, c.wantMinute)
+		}
+		if c.checkWeekday && got.Weekday() != c.wantWeekday {
+			t.Errorf("ParseOffset(%q) landed on %v, want %v", c.expr, got.Weekday(), c.wantWeekday)
+		}
+		if c.wantLastDayOfMonth {
+			if got.AddDate(0, 0, 1).Month() == got.Month() {
+				t.Errorf("ParseOffset(%q) = %v, not the last day of the month", c.expr, got)
+			}
+		}
+	}
+}
+
+func TestParseOffsetUnrecognized(t *testing.T) {
+	if _, err := ParseOffset(refNow, "not a time expression"); err == nil {
+		t.Fatal("expected an error for an unrecognized expression")
+	}
+}
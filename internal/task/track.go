@@ -0,0 +1,41 @@
+package task
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StartWithOffset records a "start" annotation on task id as of now offset by
+// the natural-language or signed-duration expression in offsetStr, e.g.
+// "-15m" begins tracking as of 15 minutes ago.
+func StartWithOffset(id int, offsetStr string) error {
+	ts, err := ParseOffset(time.Now(), offsetStr)
+	if err != nil {
+		return fmt.Errorf("parse start offset: %w", err)
+	}
+	return trackAnnotate(id, "start", ts)
+}
+
+// StopWithOffset records a "stop" annotation on task id as of now offset by
+// offsetStr, letting tracking be closed out retroactively, e.g. "yesterday
+// 17:20".
+func StopWithOffset(id int, offsetStr string) error {
+	ts, err := ParseOffset(time.Now(), offsetStr)
+	if err != nil {
+		return fmt.Errorf("parse stop offset: %w", err)
+	}
+	return trackAnnotate(id, "stop", ts)
+}
+
+func trackAnnotate(id int, kind string, ts time.Time) error {
+	note := fmt.Sprintf("%s:%s", kind, ts.Format("2006-01-02T15:04:05"))
+	cmd := exec.Command("task", strconv.Itoa(id), "annotate", note)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("task annotate %s: %w: %s", kind, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
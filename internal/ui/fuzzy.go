@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// fuzzyPattern holds a pre-processed pattern for repeated Smith-Waterman-style
+// subsequence scoring.
+type fuzzyPattern struct {
+	raw   string
+	lower string
+}
+
+// fuzzyPatternCache mirrors searchRegexCache but for compiled fuzzy patterns,
+// so submitting the same fuzzy query again reuses the lowercased pattern.
+var fuzzyPatternCache = make(map[string]*fuzzyPattern)
+
+// compileAndCacheFuzzy normalizes pattern for fuzzy matching and caches it,
+// the same way compileAndCacheRegex caches compiled regexes.
+func compileAndCacheFuzzy(pattern string) *fuzzyPattern {
+	if cached, ok := fuzzyPatternCache[pattern]; ok {
+		return cached
+	}
+	fp := &fuzzyPattern{raw: pattern, lower: strings.ToLower(pattern)}
+	fuzzyPatternCache[pattern] = fp
+	return fp
+}
+
+// Scoring weights for fuzzyScore's Smith-Waterman-style subsequence match.
+const (
+	fuzzyScoreMatch        = 16
+	fuzzyScoreConsecutive  = 8
+	fuzzyScoreWordBoundary = 12
+	fuzzyScoreGapPenalty   = 1
+)
+
+// fuzzyScore matches fp against target as an ordered character subsequence.
+// Contiguous runs and matches landing on a word boundary (start of string, or
+// right after a space/-/_/.) score bonus points; gaps between matched
+// characters subtract. ok is false when fp does not match as a subsequence of
+// target at all.
+func fuzzyScore(fp *fuzzyPattern, target string) (score int, positions []int, ok bool) {
+	tr := []rune(strings.ToLower(target))
+	pr := []rune(fp.lower)
+	if len(pr) == 0 || len(pr) > len(tr) {
+		return 0, nil, false
+	}
+
+	positions = make([]int, 0, len(pr))
+	pi := 0
+	lastMatch := -2
+	for ti := 0; ti < len(tr) && pi < len(pr); ti++ {
+		if tr[ti] != pr[pi] {
+			continue
+		}
+		s := fuzzyScoreMatch
+		if ti == lastMatch+1 {
+			s += fuzzyScoreConsecutive
+		} else {
+			if isWordBoundary(tr, ti) {
+				s += fuzzyScoreWordBoundary
+			}
+			if lastMatch >= 0 {
+				s -= fuzzyScoreGapPenalty * (ti - lastMatch - 1)
+			}
+		}
+		score += s
+		positions = append(positions, ti)
+		lastMatch = ti
+		pi++
+	}
+	if pi != len(pr) {
+		return 0, nil, false
+	}
+	return score, positions, true
+}
+
+func isWordBoundary(r []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	switch r[i-1] {
+	case ' ', '-', '_', '.':
+		return true
+	}
+	return false
+}
+
+// fuzzyMatchRow scores a table row by stripping ANSI escapes from each
+// rendered cell (the same way getSelectedTaskID strips the ID column before
+// parsing it), concatenating the result (joined with a single space) and
+// running fuzzyScore over it. It then maps each matched rune offset back to
+// the cell it falls in and its offset within that cell, and reports the
+// first matched cell as col, the same cell a regex hit's match would land
+// the cursor on.
+func fuzzyMatchRow(fp *fuzzyPattern, row []string) (score, col int, cellPositions map[int][]int, ok bool) {
+	stripped := make([]string, len(row))
+	for i, cell := range row {
+		stripped[i] = ansi.Strip(cell)
+	}
+
+	score, positions, ok := fuzzyScore(fp, strings.Join(stripped, " "))
+	if !ok {
+		return 0, 0, nil, false
+	}
+
+	// bounds[c] is the rune offset in the joined string where cell c starts.
+	bounds := make([]int, len(stripped))
+	offset := 0
+	for i, cell := range stripped {
+		bounds[i] = offset
+		offset += len([]rune(cell)) + 1 // +1 for the joining space
+	}
+
+	cellPositions = make(map[int][]int)
+	col = -1
+	for _, pos := range positions {
+		c := 0
+		for c+1 < len(bounds) && bounds[c+1] <= pos {
+			c++
+		}
+		cellPositions[c] = append(cellPositions[c], pos-bounds[c])
+		if col == -1 {
+			col = c
+		}
+	}
+	if col == -1 {
+		col = 0
+	}
+	return score, col, cellPositions, true
+}
+
+// collectFuzzyMatches scans rows for fp, returning hits sorted by descending
+// score (each landing on the cell its match starts in, not a fixed column)
+// along with the matched rune positions per row and per cell, so the cell
+// highlighting can key off the same cell boundaries as a regex hit.
+func collectFuzzyMatches(fp *fuzzyPattern, rows [][]string) ([]searchMatch, map[int]map[int][]int) {
+	type scored struct {
+		match searchMatch
+		score int
+	}
+
+	var hits []scored
+	positions := make(map[int]map[int][]int)
+	for i, row := range rows {
+		score, col, cellPositions, ok := fuzzyMatchRow(fp, row)
+		if !ok {
+			continue
+		}
+		hits = append(hits, scored{match: searchMatch{row: i, col: col}, score: score})
+		positions[i] = cellPositions
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+	matches := make([]searchMatch, len(hits))
+	for i, h := range hits {
+		matches[i] = h.match
+	}
+	return matches, positions
+}
+
+// fuzzyMatchLines is the same scan as collectFuzzyMatches but over plain text
+// lines rather than table rows, used by help search and task detail search.
+func fuzzyMatchLines(fp *fuzzyPattern, lines []string) []int {
+	type scored struct {
+		index int
+		score int
+	}
+
+	var hits []scored
+	for i, line := range lines {
+		if score, _, ok := fuzzyScore(fp, line); ok {
+			hits = append(hits, scored{index: i, score: score})
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+	indexes := make([]int, len(hits))
+	for i, h := range hits {
+		indexes[i] = h.index
+	}
+	return indexes
+}
@@ -0,0 +1,87 @@
+package ui
+
+import "testing"
+
+func TestFuzzyScoreSubsequence(t *testing.T) {
+	fp := compileAndCacheFuzzy("tsk")
+	score, positions, ok := fuzzyScore(fp, "task")
+	if !ok {
+		t.Fatalf("expected %q to match %q as a subsequence", "tsk", "task")
+	}
+	if len(positions) != 3 {
+		t.Fatalf("expected 3 matched positions, got %v", positions)
+	}
+	if score <= 0 {
+		t.Fatalf("expected a positive score, got %d", score)
+	}
+}
+
+func TestFuzzyScoreNoMatch(t *testing.T) {
+	fp := compileAndCacheFuzzy("xyz")
+	if _, _, ok := fuzzyScore(fp, "task"); ok {
+		t.Fatalf("expected %q not to match %q", "xyz", "task")
+	}
+}
+
+func TestFuzzyMatchRowReportsMatchedCell(t *testing.T) {
+	fp := compileAndCacheFuzzy("wip")
+	row := []string{"1", "H", "urgent wip review"}
+
+	score, col, cellPositions, ok := fuzzyMatchRow(fp, row)
+	if !ok {
+		t.Fatalf("expected row to match")
+	}
+	if score <= 0 {
+		t.Fatalf("expected a positive score, got %d", score)
+	}
+	if col != 2 {
+		t.Fatalf("expected the match to land on cell 2, got %d", col)
+	}
+	if len(cellPositions[2]) == 0 {
+		t.Fatalf("expected matched positions recorded against cell 2, got %v", cellPositions)
+	}
+	if _, ok := cellPositions[0]; ok {
+		t.Fatalf("did not expect any match recorded against cell 0, got %v", cellPositions)
+	}
+}
+
+func TestFuzzyMatchRowStripsANSI(t *testing.T) {
+	fp := compileAndCacheFuzzy("wip")
+	row := []string{"\x1b[31m1\x1b[0m", "\x1b[1mH\x1b[0m", "\x1b[32murgent wip review\x1b[0m"}
+
+	score, col, cellPositions, ok := fuzzyMatchRow(fp, row)
+	if !ok {
+		t.Fatalf("expected row with ANSI-escaped cells to match")
+	}
+	if score <= 0 {
+		t.Fatalf("expected a positive score, got %d", score)
+	}
+	if col != 2 {
+		t.Fatalf("expected the match to land on cell 2, got %d", col)
+	}
+	for _, pos := range cellPositions[2] {
+		if pos < 0 || pos >= len([]rune("urgent wip review")) {
+			t.Fatalf("matched position %d falls outside the visible cell text, ANSI escapes leaked into the offsets", pos)
+		}
+	}
+}
+
+func TestCollectFuzzyMatchesSortsByScore(t *testing.T) {
+	fp := compileAndCacheFuzzy("wip")
+	rows := [][]string{
+		{"1", "no match here"},
+		{"2", "w i p far apart"},
+		{"3", "wip"},
+	}
+
+	matches, positions := collectFuzzyMatches(fp, rows)
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d: %v", len(matches), matches)
+	}
+	if matches[0].row != 2 {
+		t.Fatalf("expected the tighter match (row 2) to rank first, got row %d", matches[0].row)
+	}
+	if _, ok := positions[matches[0].row]; !ok {
+		t.Fatalf("expected cell positions recorded for the top match's row")
+	}
+}
@@ -2,15 +2,19 @@ package ui
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/x/ansi"
 
 	"codeberg.org/snonux/tasksamurai/internal/task"
+	"codeberg.org/snonux/tasksamurai/internal/task/filterdsl"
+	"codeberg.org/snonux/tasksamurai/internal/task/history"
 )
 
 // handleTextInput provides generic text input handling for all input modes
@@ -47,30 +51,45 @@ func (m *Model) handleAnnotationMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if !m.replaceAnnotations && strings.TrimSpace(value) == "" {
 			return fmt.Errorf("annotation cannot be empty")
 		}
-		
-		if m.replaceAnnotations {
-			if err := task.ReplaceAnnotations(m.annotateID, value); err != nil {
-				return err
+
+		ids := m.targetIDs(m.annotateID)
+		for i, id := range ids {
+			var old string
+			if t := m.taskByID(id); t != nil {
+				old = strings.Join(t.Annotations, "\n")
 			}
-			m.replaceAnnotations = false
-		} else {
-			if err := task.Annotate(m.annotateID, value); err != nil {
-				return err
+
+			var err error
+			if m.replaceAnnotations {
+				err = task.ReplaceAnnotations(id, value)
+			} else {
+				err = task.Annotate(id, value)
 			}
+			if err != nil {
+				return fmt.Errorf("task %d: %w", id, err)
+			}
+
+			newVal := value
+			if !m.replaceAnnotations && old != "" {
+				newVal = old + "\n" + value
+			}
+			_ = m.history.Push(history.Op{ID: id, Action: "annotation", OldValue: old, NewValue: newVal, At: time.Now()})
+			m.statusMsg = fmt.Sprintf("Annotating %d/%d", i+1, len(ids))
 		}
+		m.replaceAnnotations = false
 		m.reload()
 		return nil
 	}
-	
+
 	onExit := func() {
 		m.annotating = false
 		m.replaceAnnotations = false
 	}
-	
+
 	model, cmd := m.handleTextInput(msg, &m.annotateInput, onEnter, onExit)
 	if msg.Type == tea.KeyEnter && m.annotateInput.Value() != "" {
 		// Start blink after successful annotation
-		return model, m.startBlink(m.annotateID, false)
+		return model, m.startBlink(m.targetIDs(m.annotateID), false)
 	}
 	return model, cmd
 }
@@ -81,20 +100,29 @@ func (m *Model) handleDescriptionMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if err := validateDescription(value); err != nil {
 			return err
 		}
-		if err := task.SetDescription(m.descID, value); err != nil {
-			return err
+		ids := m.targetIDs(m.descID)
+		for i, id := range ids {
+			var old string
+			if t := m.taskByID(id); t != nil {
+				old = t.Description
+			}
+			if err := task.SetDescription(id, value); err != nil {
+				return fmt.Errorf("task %d: %w", id, err)
+			}
+			_ = m.history.Push(history.Op{ID: id, Action: "description", OldValue: old, NewValue: value, At: time.Now()})
+			m.statusMsg = fmt.Sprintf("Updating description %d/%d", i+1, len(ids))
 		}
 		m.reload()
 		return nil
 	}
-	
+
 	onExit := func() {
 		m.descEditing = false
 	}
-	
+
 	model, cmd := m.handleTextInput(msg, &m.descInput, onEnter, onExit)
 	if msg.Type == tea.KeyEnter {
-		return model, m.startBlink(m.descID, false)
+		return model, m.startBlink(m.targetIDs(m.descID), false)
 	}
 	return model, cmd
 }
@@ -125,45 +153,76 @@ func (m *Model) handleTagsMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 		}
-		if len(adds) > 0 {
-			if err := task.AddTags(m.tagsID, adds); err != nil {
-				return err
+		ids := m.targetIDs(m.tagsID)
+		for i, id := range ids {
+			var oldTags []string
+			if t := m.taskByID(id); t != nil {
+				oldTags = append(oldTags, t.Tags...)
 			}
-		}
-		if len(removes) > 0 {
-			if err := task.RemoveTags(m.tagsID, removes); err != nil {
-				return err
+
+			if len(adds) > 0 {
+				if err := task.AddTags(id, adds); err != nil {
+					return fmt.Errorf("task %d: %w", id, err)
+				}
 			}
+			if len(removes) > 0 {
+				if err := task.RemoveTags(id, removes); err != nil {
+					return fmt.Errorf("task %d: %w", id, err)
+				}
+			}
+
+			newTags := applyTagDelta(oldTags, adds, removes)
+			_ = m.history.Push(history.Op{
+				ID: id, Action: "tags",
+				OldValue: strings.Join(oldTags, ","),
+				NewValue: strings.Join(newTags, ","),
+				At:       time.Now(),
+			})
+			m.statusMsg = fmt.Sprintf("Tagging %d/%d", i+1, len(ids))
 		}
 		m.reload()
 		return nil
 	}
-	
+
 	onExit := func() {
 		m.tagsEditing = false
 	}
-	
+
 	model, cmd := m.handleTextInput(msg, &m.tagsInput, onEnter, onExit)
 	if msg.Type == tea.KeyEnter {
 		if m.showTaskDetail {
 			// In detail view, blink the tags field
 			return model, m.startDetailBlink(4) // Tags is field index 4
 		}
-		return model, m.startBlink(m.tagsID, false)
+		return model, m.startBlink(m.targetIDs(m.tagsID), false)
 	}
 	return model, cmd
 }
 
 // handleDueEditMode handles due date editing
 func (m *Model) handleDueEditMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.dueTextEditing {
+		return m.handleDueTextEditMode(msg)
+	}
+
 	switch msg.Type {
 	case tea.KeyEnter:
-		if err := task.SetDueDate(m.dueID, m.dueDate.Format("2006-01-02")); err != nil {
-			m.statusMsg = fmt.Sprintf("Error: %v", err)
-			cmd := tea.Tick(2*time.Second, func(time.Time) tea.Msg {
-				return struct{ clearStatus bool }{true}
-			})
-			return m, cmd
+		ids := m.targetIDs(m.dueID)
+		dueStr := m.dueDate.Format("2006-01-02")
+		for i, id := range ids {
+			var old string
+			if t := m.taskByID(id); t != nil {
+				old = t.Due
+			}
+			if err := task.SetDueDate(id, dueStr); err != nil {
+				m.statusMsg = fmt.Sprintf("Error: %v", err)
+				cmd := tea.Tick(2*time.Second, func(time.Time) tea.Msg {
+					return struct{ clearStatus bool }{true}
+				})
+				return m, cmd
+			}
+			_ = m.history.Push(history.Op{ID: id, Action: "due", OldValue: old, NewValue: dueStr, At: time.Now()})
+			m.statusMsg = fmt.Sprintf("Setting due date %d/%d", i+1, len(ids))
 		}
 		m.dueEditing = false
 		m.reload()
@@ -172,7 +231,7 @@ func (m *Model) handleDueEditMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// In detail view, blink the due field
 			cmd = m.startDetailBlink(5) // Due is field index 5
 		} else {
-			cmd = m.startBlink(m.dueID, false)
+			cmd = m.startBlink(ids, false)
 		}
 		m.updateTableHeight()
 		return m, cmd
@@ -191,27 +250,100 @@ func (m *Model) handleDueEditMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.dueDate = m.dueDate.AddDate(0, 0, -7)
 	case "j", "down":
 		m.dueDate = m.dueDate.AddDate(0, 0, 7)
+	case "i":
+		m.dueTextEditing = true
+		m.dueTextInput.SetValue("")
+		m.dueTextInput.Focus()
 	}
 	return m, nil
 }
 
+// handleDueTextEditMode handles the natural-language text entry sub-mode of
+// the due date calendar, toggled with 'i'. Expressions such as "tomorrow",
+// "next fri", "in 2 weeks" or "-1d" are parsed via task.ParseOffset and feed
+// back into m.dueDate without leaving the calendar, so Enter there still
+// commits the date.
+func (m *Model) handleDueTextEditMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	onEnter := func(value string) error {
+		ts, err := task.ParseOffset(time.Now(), value)
+		if err != nil {
+			return err
+		}
+		m.dueDate = ts
+		return nil
+	}
+
+	onExit := func() {
+		m.dueTextEditing = false
+	}
+
+	return m.handleTextInput(msg, &m.dueTextInput, onEnter, onExit)
+}
+
+// handleTrackStartMode handles the time-tracking start annotation input,
+// toggled with '(' from the task list.
+func (m *Model) handleTrackStartMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	onEnter := func(value string) error {
+		return task.StartWithOffset(m.trackID, value)
+	}
+
+	onExit := func() {
+		m.trackStarting = false
+	}
+
+	model, cmd := m.handleTextInput(msg, &m.trackInput, onEnter, onExit)
+	if msg.Type == tea.KeyEnter {
+		m.reload()
+		return model, m.startBlink([]int{m.trackID}, false)
+	}
+	return model, cmd
+}
+
+// handleTrackStopMode handles the time-tracking stop annotation input,
+// toggled with ')' from the task list.
+func (m *Model) handleTrackStopMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	onEnter := func(value string) error {
+		return task.StopWithOffset(m.trackID, value)
+	}
+
+	onExit := func() {
+		m.trackStopping = false
+	}
+
+	model, cmd := m.handleTextInput(msg, &m.trackInput, onEnter, onExit)
+	if msg.Type == tea.KeyEnter {
+		m.reload()
+		return model, m.startBlink([]int{m.trackID}, false)
+	}
+	return model, cmd
+}
+
 // handleRecurrenceMode handles recurrence editing
 func (m *Model) handleRecurrenceMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	onEnter := func(value string) error {
 		if err := validateRecurrence(value); err != nil {
 			return err
 		}
-		if err := task.SetRecurrence(m.recurID, value); err != nil {
-			return err
+		ids := m.targetIDs(m.recurID)
+		for i, id := range ids {
+			var old string
+			if t := m.taskByID(id); t != nil {
+				old = t.Recur
+			}
+			if err := task.SetRecurrence(id, value); err != nil {
+				return fmt.Errorf("task %d: %w", id, err)
+			}
+			_ = m.history.Push(history.Op{ID: id, Action: "recurrence", OldValue: old, NewValue: value, At: time.Now()})
+			m.statusMsg = fmt.Sprintf("Setting recurrence %d/%d", i+1, len(ids))
 		}
 		m.reload()
 		return nil
 	}
-	
+
 	onExit := func() {
 		m.recurEditing = false
 	}
-	
+
 	model, cmd := m.handleTextInput(msg, &m.recurInput, onEnter, onExit)
 	if msg.Type == tea.KeyEnter {
 		if m.showTaskDetail {
@@ -222,7 +354,7 @@ func (m *Model) handleRecurrenceMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return model, m.startDetailBlink(fieldIndex)
 			}
 		}
-		return model, m.startBlink(m.recurID, false)
+		return model, m.startBlink(m.targetIDs(m.recurID), false)
 	}
 	return model, cmd
 }
@@ -230,21 +362,33 @@ func (m *Model) handleRecurrenceMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // handleProjectMode handles project editing
 func (m *Model) handleProjectMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	onEnter := func(value string) error {
-		return task.SetProject(m.projID, value)
+		ids := m.targetIDs(m.projID)
+		for i, id := range ids {
+			var old string
+			if t := m.taskByID(id); t != nil {
+				old = t.Project
+			}
+			if err := task.SetProject(id, value); err != nil {
+				return fmt.Errorf("task %d: %w", id, err)
+			}
+			_ = m.history.Push(history.Op{ID: id, Action: "project", OldValue: old, NewValue: value, At: time.Now()})
+			m.statusMsg = fmt.Sprintf("Setting project %d/%d", i+1, len(ids))
+		}
+		return nil
 	}
-	
+
 	onExit := func() {
 		m.projEditing = false
 		m.reload()
 	}
-	
+
 	model, cmd := m.handleTextInput(msg, &m.projInput, onEnter, onExit)
 	if msg.Type == tea.KeyEnter {
 		if m.showTaskDetail {
 			// In detail view, blink the project field
 			return model, m.startDetailBlink(fieldProject) // Project field index in detail view
 		}
-		return model, m.startBlink(m.projID, false)
+		return model, m.startBlink(m.targetIDs(m.projID), false)
 	}
 	return model, cmd
 }
@@ -261,12 +405,21 @@ func (m *Model) handlePriorityMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			})
 			return m, cmd
 		}
-		if err := task.SetPriority(m.priorityID, priority); err != nil {
-			m.statusMsg = fmt.Sprintf("Error: %v", err)
-			cmd := tea.Tick(2*time.Second, func(time.Time) tea.Msg {
-				return struct{ clearStatus bool }{true}
-			})
-			return m, cmd
+		ids := m.targetIDs(m.priorityID)
+		for i, id := range ids {
+			var old string
+			if t := m.taskByID(id); t != nil {
+				old = t.Priority
+			}
+			if err := task.SetPriority(id, priority); err != nil {
+				m.statusMsg = fmt.Sprintf("Error: %v", err)
+				cmd := tea.Tick(2*time.Second, func(time.Time) tea.Msg {
+					return struct{ clearStatus bool }{true}
+				})
+				return m, cmd
+			}
+			_ = m.history.Push(history.Op{ID: id, Action: "priority", OldValue: old, NewValue: priority, At: time.Now()})
+			m.statusMsg = fmt.Sprintf("Setting priority %d/%d", i+1, len(ids))
 		}
 		m.prioritySelecting = false
 		m.reload()
@@ -275,7 +428,7 @@ func (m *Model) handlePriorityMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// In detail view, blink the priority field
 			cmd = m.startDetailBlink(3) // Priority is field index 3
 		} else {
-			cmd = m.startBlink(m.priorityID, false)
+			cmd = m.startBlink(ids, false)
 		}
 		m.updateTableHeight()
 		return m, cmd
@@ -294,21 +447,62 @@ func (m *Model) handlePriorityMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// handleFilterMode handles filter editing
+// handleFilterMode handles filter editing. It first tries to parse the input
+// as a filterdsl expression (e.g. "project:work AND (priority:H OR due<+3d)");
+// when that parses cleanly the filter is applied client-side over m.tasks and
+// the taskwarrior filter shell-out is skipped. When it fails to parse, the
+// input falls back to the previous behavior of being split on whitespace and
+// handed to taskwarrior as-is.
 func (m *Model) handleFilterMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	onEnter := func(value string) error {
-		m.filters = strings.Fields(value)
+		expr, err := filterdsl.Parse(value)
+		if err != nil {
+			m.filterExpr = nil
+			m.filters = strings.Fields(value)
+			if value != "" {
+				m.statusMsg = fmt.Sprintf("Filter: plain taskwarrior syntax (%v)", err)
+			}
+			m.reload()
+			return nil
+		}
+
+		m.filterExpr = expr
+		m.filters = nil
+		m.statusMsg = "Filter: DSL"
 		m.reload()
+		m.applyFilterExpr(expr)
 		return nil
 	}
-	
+
 	onExit := func() {
 		m.filterEditing = false
 	}
-	
+
 	return m.handleTextInput(msg, &m.filterInput, onEnter, onExit)
 }
 
+// applyFilterExpr narrows m.tasks down to whatever matches expr. reload()
+// just populated both m.tasks and the table's rows from the unfiltered
+// fetch (the DSL filter runs client-side, so m.filters was cleared), so the
+// two are filtered in lockstep here to keep cursor-indexed lookups like
+// getTaskAtCursor valid against the narrowed table.
+func (m *Model) applyFilterExpr(expr filterdsl.Expr) {
+	rows := m.tbl.Rows()
+	filteredTasks := m.tasks[:0:0]
+	filteredRows := make([]table.Row, 0, len(rows))
+	for i, tsk := range m.tasks {
+		if !expr.Evaluate(tsk) {
+			continue
+		}
+		filteredTasks = append(filteredTasks, tsk)
+		if i < len(rows) {
+			filteredRows = append(filteredRows, rows[i])
+		}
+	}
+	m.tasks = filteredTasks
+	m.tbl.SetRows(filteredRows)
+}
+
 // handleAddTaskMode handles adding a new task
 func (m *Model) handleAddTaskMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.Type {
@@ -318,18 +512,19 @@ func (m *Model) handleAddTaskMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			oldIDs[tsk.ID] = struct{}{}
 		}
 		
-		if err := task.AddLine(m.addInput.Value()); err != nil {
+		addLine := m.addInput.Value()
+		if err := task.AddLine(addLine); err != nil {
 			m.statusMsg = fmt.Sprintf("Error: %v", err)
 			cmd := tea.Tick(2*time.Second, func(time.Time) tea.Msg {
 				return struct{ clearStatus bool }{true}
 			})
 			return m, cmd
 		}
-		
+
 		m.addingTask = false
 		m.addInput.Blur()
 		m.reload()
-		
+
 		// Find the newly added task
 		var newID int
 		row := -1
@@ -340,15 +535,16 @@ func (m *Model) handleAddTaskMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				break
 			}
 		}
-		
+
 		m.updateTableHeight()
 		if row >= 0 {
+			_ = m.history.Push(history.Op{ID: newID, Action: "add", OldValue: "", NewValue: addLine, At: time.Now()})
 			prevRow := m.tbl.Cursor()
 			prevCol := m.tbl.ColumnCursor()
 			m.tbl.SetCursor(row)
 			m.tbl.SetColumnCursor(7) // Description column
 			m.updateSelectionHighlight(prevRow, m.tbl.Cursor(), prevCol, m.tbl.ColumnCursor())
-			return m, m.startBlink(newID, false)
+			return m, m.startBlink([]int{newID}, false)
 		}
 		return m, nil
 		
@@ -367,30 +563,53 @@ func (m *Model) handleAddTaskMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // handleSearchMode handles search input
 func (m *Model) handleSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.Type {
+	case tea.KeyCtrlF:
+		m.searchFuzzy = !m.searchFuzzy
+		if m.searchFuzzy {
+			m.statusMsg = "Fuzzy search"
+		} else {
+			m.statusMsg = "Regex search"
+		}
+		return m, nil
+
 	case tea.KeyEnter:
 		pattern := m.searchInput.Value()
+		m.fuzzyMatchPositions = nil
 		if pattern != "" {
-			// Check cache first
-			if cached, ok := searchRegexCache[pattern]; ok {
-				m.searchRegex = cached
+			if m.searchFuzzy {
+				m.searchRegex = nil
+				m.searchFuzzyPattern = compileAndCacheFuzzy(pattern)
 			} else {
-				// Compile and cache if not found
-				re, err := compileAndCacheRegex(pattern)
-				if err == nil {
-					m.searchRegex = re
+				m.searchFuzzyPattern = nil
+				// Check cache first
+				if cached, ok := searchRegexCache[pattern]; ok {
+					m.searchRegex = cached
 				} else {
-					m.searchRegex = nil
-					m.statusMsg = fmt.Sprintf("Invalid regex: %v", err)
+					// Compile and cache if not found
+					re, err := compileAndCacheRegex(pattern)
+					if err == nil {
+						m.searchRegex = re
+					} else {
+						m.searchRegex = nil
+						m.statusMsg = fmt.Sprintf("Invalid regex: %v", err)
+					}
 				}
 			}
 		} else {
 			m.searchRegex = nil
+			m.searchFuzzyPattern = nil
 		}
 		m.searching = false
 		m.searchInput.Blur()
 		m.reload()
+		if m.searchFuzzyPattern != nil {
+			matches, positions := collectFuzzyMatches(m.searchFuzzyPattern, m.tbl.Rows())
+			m.searchMatches = matches
+			m.fuzzyMatchPositions = positions
+			m.searchIndex = 0
+		}
 		m.updateTableHeight()
-		
+
 		if len(m.searchMatches) > 0 {
 			match := m.searchMatches[m.searchIndex]
 			prevRow := m.tbl.Cursor()
@@ -400,14 +619,14 @@ func (m *Model) handleSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.updateSelectionHighlight(prevRow, m.tbl.Cursor(), prevCol, m.tbl.ColumnCursor())
 		}
 		return m, nil
-		
+
 	case tea.KeyEsc:
 		m.searching = false
 		m.searchInput.Blur()
 		m.updateTableHeight()
 		return m, nil
 	}
-	
+
 	var cmd tea.Cmd
 	m.searchInput, cmd = m.searchInput.Update(msg)
 	return m, cmd
@@ -416,9 +635,24 @@ func (m *Model) handleSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // handleHelpSearchMode handles search input in help mode
 func (m *Model) handleHelpSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.Type {
+	case tea.KeyCtrlF:
+		m.helpSearchFuzzy = !m.helpSearchFuzzy
+		if m.helpSearchFuzzy {
+			m.statusMsg = "Fuzzy search"
+		} else {
+			m.statusMsg = "Regex search"
+		}
+		return m, nil
+
 	case tea.KeyEnter:
 		pattern := m.helpSearchInput.Value()
-		if pattern != "" {
+		m.helpSearchMatches = nil
+
+		if pattern != "" && m.helpSearchFuzzy {
+			m.helpSearchRegex = nil
+			fp := compileAndCacheFuzzy(pattern)
+			m.helpSearchMatches = fuzzyMatchLines(fp, m.getHelpLines())
+		} else if pattern != "" {
 			// Check cache first
 			if cached, ok := searchRegexCache[pattern]; ok {
 				m.helpSearchRegex = cached
@@ -432,34 +666,32 @@ func (m *Model) handleHelpSearchMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					m.statusMsg = fmt.Sprintf("Invalid regex: %v", err)
 				}
 			}
+			if m.helpSearchRegex != nil {
+				helpLines := m.getHelpLines()
+				for i, line := range helpLines {
+					if m.helpSearchRegex.MatchString(line) {
+						m.helpSearchMatches = append(m.helpSearchMatches, i)
+					}
+				}
+			}
 		} else {
 			m.helpSearchRegex = nil
 		}
+
+		// Set to first match
+		if len(m.helpSearchMatches) > 0 {
+			m.helpSearchIndex = 0
+		}
 		m.helpSearching = false
 		m.helpSearchInput.Blur()
-		
-		// Find matching help lines
-		m.helpSearchMatches = nil
-		if m.helpSearchRegex != nil {
-			helpLines := m.getHelpLines()
-			for i, line := range helpLines {
-				if m.helpSearchRegex.MatchString(line) {
-					m.helpSearchMatches = append(m.helpSearchMatches, i)
-				}
-			}
-			// Set to first match
-			if len(m.helpSearchMatches) > 0 {
-				m.helpSearchIndex = 0
-			}
-		}
 		return m, nil
-		
+
 	case tea.KeyEsc:
 		m.helpSearching = false
 		m.helpSearchInput.Blur()
 		return m, nil
 	}
-	
+
 	var cmd tea.Cmd
 	m.helpSearchInput, cmd = m.helpSearchInput.Update(msg)
 	return m, cmd
@@ -508,6 +740,12 @@ func (m *Model) handleEditingModes(msg tea.KeyMsg) (handled bool, model tea.Mode
 	case m.filterEditing:
 		model, cmd = m.handleFilterMode(msg)
 		return true, model, cmd
+	case m.bulkConfirming:
+		model, cmd = m.handleBulkConfirmMode(msg)
+		return true, model, cmd
+	case m.showHistory:
+		model, cmd = m.handleHistoryMode(msg)
+		return true, model, cmd
 	case m.addingTask:
 		model, cmd = m.handleAddTaskMode(msg)
 		return true, model, cmd
@@ -517,10 +755,418 @@ func (m *Model) handleEditingModes(msg tea.KeyMsg) (handled bool, model tea.Mode
 	case m.helpSearching:
 		model, cmd = m.handleHelpSearchMode(msg)
 		return true, model, cmd
+	case m.trackStarting:
+		model, cmd = m.handleTrackStartMode(msg)
+		return true, model, cmd
+	case m.trackStopping:
+		model, cmd = m.handleTrackStopMode(msg)
+		return true, model, cmd
+	}
+
+	// Not already in an editing mode: keys that open one.
+	switch msg.String() {
+	case "(":
+		id, err := m.getSelectedTaskID()
+		if err != nil {
+			return false, m, nil
+		}
+		m.trackID = id
+		m.trackStarting = true
+		m.trackInput.SetValue("")
+		m.trackInput.Focus()
+		m.updateTableHeight()
+		return true, m, nil
+	case ")":
+		id, err := m.getSelectedTaskID()
+		if err != nil {
+			return false, m, nil
+		}
+		m.trackID = id
+		m.trackStopping = true
+		m.trackInput.SetValue("")
+		m.trackInput.Focus()
+		m.updateTableHeight()
+		return true, m, nil
+	case "v":
+		tsk := m.getTaskAtCursor()
+		if tsk == nil {
+			return false, m, nil
+		}
+		m.toggleSelected(tsk.ID)
+		m.visualAnchor = tsk.ID
+		return true, m, nil
+	case "V":
+		tsk := m.getTaskAtCursor()
+		if tsk == nil {
+			return false, m, nil
+		}
+		m.selectRange(m.visualAnchor, tsk.ID)
+		return true, m, nil
+	case "*":
+		m.selectAllFiltered()
+		return true, m, nil
+	case "D":
+		id, err := m.getSelectedTaskID()
+		if err != nil {
+			return false, m, nil
+		}
+		m.bulkID = id
+		m.bulkAction = "done"
+		m.bulkConfirming = true
+		return true, m, nil
+	case "X":
+		id, err := m.getSelectedTaskID()
+		if err != nil {
+			return false, m, nil
+		}
+		m.bulkID = id
+		m.bulkAction = "delete"
+		m.bulkConfirming = true
+		return true, m, nil
+	case "u":
+		model, cmd = m.Undo()
+		return true, model, cmd
+	case "ctrl+r":
+		model, cmd = m.Redo()
+		return true, model, cmd
+	case "H":
+		m.showHistory = true
+		m.historyIndex = 0
+		return true, m, nil
 	}
 	return false, m, nil
 }
 
+// taskByID returns the currently loaded snapshot of the task with the given
+// ID, or nil if it isn't loaded (e.g. it's been filtered out), for
+// snapshotting a field's previous value before a mutation.
+func (m *Model) taskByID(id int) *task.Task {
+	for i := range m.tasks {
+		if m.tasks[i].ID == id {
+			return &m.tasks[i]
+		}
+	}
+	return nil
+}
+
+// applyTagDelta returns the tag set that results from applying adds/removes
+// to old, sorted for a stable, comparable snapshot.
+func applyTagDelta(old, adds, removes []string) []string {
+	set := make(map[string]struct{}, len(old))
+	for _, t := range old {
+		set[t] = struct{}{}
+	}
+	for _, t := range removes {
+		delete(set, t)
+	}
+	for _, t := range adds {
+		set[t] = struct{}{}
+	}
+	out := make([]string, 0, len(set))
+	for t := range set {
+		out = append(out, t)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Undo pops the most recent history op and replays its inverse against
+// taskwarrior; bound to 'u' in the top-level key handler.
+func (m *Model) Undo() (tea.Model, tea.Cmd) {
+	op, ok := m.history.Undo()
+	if !ok {
+		m.statusMsg = "Nothing to undo"
+		return m, nil
+	}
+	if err := applyHistoryValue(op, true); err != nil {
+		m.statusMsg = fmt.Sprintf("Undo failed: %v", err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Undid %s on task %d", op.Action, op.ID)
+	m.reload()
+	return m, m.startBlink([]int{op.ID}, false)
+}
+
+// Redo re-applies the most recently undone history op; bound to Ctrl-R in
+// the top-level key handler.
+func (m *Model) Redo() (tea.Model, tea.Cmd) {
+	op, ok := m.history.Redo()
+	if !ok {
+		m.statusMsg = "Nothing to redo"
+		return m, nil
+	}
+	if op.Action == "add" {
+		return m.redoAdd(op)
+	}
+	if err := applyHistoryValue(op, false); err != nil {
+		m.statusMsg = fmt.Sprintf("Redo failed: %v", err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Redid %s on task %d", op.Action, op.ID)
+	m.reload()
+	return m, m.startBlink([]int{op.ID}, false)
+}
+
+// redoAdd re-adds an "add" op's line via taskwarrior, which assigns it a new
+// ID, then rewrites op.ID to that new ID and persists the correction via
+// ReplaceTop so a later Undo deletes the task it actually just recreated
+// instead of acting on the stale original ID.
+func (m *Model) redoAdd(op history.Op) (tea.Model, tea.Cmd) {
+	oldIDs := make(map[int]struct{}, len(m.tasks))
+	for _, tsk := range m.tasks {
+		oldIDs[tsk.ID] = struct{}{}
+	}
+	if err := task.AddLine(op.NewValue); err != nil {
+		m.statusMsg = fmt.Sprintf("Redo failed: %v", err)
+		return m, nil
+	}
+	m.reload()
+	for _, tsk := range m.tasks {
+		if _, ok := oldIDs[tsk.ID]; !ok {
+			op.ID = tsk.ID
+			break
+		}
+	}
+	_ = m.history.ReplaceTop(op)
+	m.statusMsg = fmt.Sprintf("Redid %s on task %d", op.Action, op.ID)
+	return m, m.startBlink([]int{op.ID}, false)
+}
+
+// applyHistoryValue replays one history op against taskwarrior: undo sets
+// the field back to op.OldValue, redo sets it back to op.NewValue. "add" has
+// no single field to restore and is special-cased by Redo itself since
+// taskwarrior assigns the recreated task a new ID; here it only ever runs
+// for undo, which deletes the task the add created.
+func applyHistoryValue(op history.Op, undo bool) error {
+	from, to := op.OldValue, op.NewValue
+	if undo {
+		from, to = op.NewValue, op.OldValue
+	}
+	switch op.Action {
+	case "description":
+		return task.SetDescription(op.ID, to)
+	case "due":
+		return task.SetDueDate(op.ID, to)
+	case "project":
+		return task.SetProject(op.ID, to)
+	case "priority":
+		return task.SetPriority(op.ID, to)
+	case "recurrence":
+		return task.SetRecurrence(op.ID, to)
+	case "annotation":
+		return task.ReplaceAnnotations(op.ID, to)
+	case "tags":
+		return retagTo(op.ID, from, to)
+	case "add":
+		if undo {
+			return task.Delete(op.ID)
+		}
+		return task.AddLine(to)
+	}
+	return fmt.Errorf("unknown history action %q", op.Action)
+}
+
+// retagTo moves task id's tags from the comma-joined set `from` to `to` via
+// AddTags/RemoveTags, since taskwarrior has no single "set tags" call.
+func retagTo(id int, from, to string) error {
+	fromSet := make(map[string]struct{})
+	for _, t := range strings.Split(from, ",") {
+		if t != "" {
+			fromSet[t] = struct{}{}
+		}
+	}
+
+	var adds, removes []string
+	for _, t := range strings.Split(to, ",") {
+		if t == "" {
+			continue
+		}
+		if _, ok := fromSet[t]; ok {
+			delete(fromSet, t)
+		} else {
+			adds = append(adds, t)
+		}
+	}
+	for t := range fromSet {
+		removes = append(removes, t)
+	}
+
+	if len(adds) > 0 {
+		if err := task.AddTags(id, adds); err != nil {
+			return err
+		}
+	}
+	if len(removes) > 0 {
+		if err := task.RemoveTags(id, removes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleHistoryMode handles keyboard input in the :history view, which lists
+// recent undo/redo ops newest-first and supports jumping to the affected
+// task.
+func (m *Model) handleHistoryMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entries := m.history.Recent(200)
+
+	switch msg.String() {
+	case "q", "esc":
+		m.showHistory = false
+		return m, nil
+	case "up", "k":
+		if m.historyIndex > 0 {
+			m.historyIndex--
+		}
+		return m, nil
+	case "down", "j":
+		if m.historyIndex < len(entries)-1 {
+			m.historyIndex++
+		}
+		return m, nil
+	case "u":
+		return m.Undo()
+	case "ctrl+r":
+		return m.Redo()
+	case "enter":
+		if m.historyIndex < 0 || m.historyIndex >= len(entries) {
+			return m, nil
+		}
+		return m.jumpToTask(entries[m.historyIndex].ID)
+	}
+	return m, nil
+}
+
+// jumpToTask closes the :history view and moves the table cursor to the row
+// for the given task ID, if it's currently loaded.
+func (m *Model) jumpToTask(id int) (tea.Model, tea.Cmd) {
+	m.showHistory = false
+	for i, tsk := range m.tasks {
+		if tsk.ID == id {
+			prevRow := m.tbl.Cursor()
+			prevCol := m.tbl.ColumnCursor()
+			m.tbl.SetCursor(i)
+			m.updateSelectionHighlight(prevRow, m.tbl.Cursor(), prevCol, m.tbl.ColumnCursor())
+			break
+		}
+	}
+	return m, nil
+}
+
+// targetIDs returns the task IDs an editing operation should apply to: every
+// ID in m.selectedIDs, sorted, when visual selection is active, otherwise
+// just the given single ID so the normal single-task path is unaffected.
+func (m *Model) targetIDs(id int) []int {
+	if len(m.selectedIDs) == 0 {
+		return []int{id}
+	}
+	ids := make([]int, 0, len(m.selectedIDs))
+	for selected := range m.selectedIDs {
+		ids = append(ids, selected)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// toggleSelected adds id to the visual selection, or removes it if it's
+// already selected, toggled with 'v' from the task list.
+func (m *Model) toggleSelected(id int) {
+	if m.selectedIDs == nil {
+		m.selectedIDs = make(map[int]struct{})
+	}
+	if _, ok := m.selectedIDs[id]; ok {
+		delete(m.selectedIDs, id)
+		return
+	}
+	m.selectedIDs[id] = struct{}{}
+}
+
+// selectRange adds every task between anchorID and cursorID (inclusive,
+// either order) in m.tasks' current order to the visual selection, toggled
+// with 'V' from the task list. If anchorID isn't set yet it falls back to
+// selecting just cursorID.
+func (m *Model) selectRange(anchorID, cursorID int) {
+	if anchorID == 0 {
+		anchorID = cursorID
+	}
+	start, end := -1, -1
+	for i, tsk := range m.tasks {
+		if tsk.ID == anchorID {
+			start = i
+		}
+		if tsk.ID == cursorID {
+			end = i
+		}
+	}
+	if start == -1 || end == -1 {
+		return
+	}
+	if start > end {
+		start, end = end, start
+	}
+	if m.selectedIDs == nil {
+		m.selectedIDs = make(map[int]struct{})
+	}
+	for _, tsk := range m.tasks[start : end+1] {
+		m.selectedIDs[tsk.ID] = struct{}{}
+	}
+}
+
+// selectAllFiltered replaces the visual selection with every task currently
+// loaded in m.tasks, i.e. everything left after the active filter, toggled
+// with '*' from the task list.
+func (m *Model) selectAllFiltered() {
+	m.selectedIDs = make(map[int]struct{}, len(m.tasks))
+	for _, tsk := range m.tasks {
+		m.selectedIDs[tsk.ID] = struct{}{}
+	}
+}
+
+// handleBulkConfirmMode handles the y/n confirmation shown before a bulk
+// "done" or "delete" is applied to every selected task (or the task under the
+// cursor when nothing is selected), looping m.bulkAction over targetIDs in a
+// single pass with progress reported through m.statusMsg. A task that fails is
+// skipped and counted; the others are still applied and nothing is rolled back.
+func (m *Model) handleBulkConfirmMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		ids := m.targetIDs(m.bulkID)
+		label := "Completing"
+		apply := task.Done
+		if m.bulkAction == "delete" {
+			label = "Deleting"
+			apply = task.Delete
+		}
+
+		var failed []int
+		for i, id := range ids {
+			if err := apply(id); err != nil {
+				failed = append(failed, id)
+				continue
+			}
+			m.statusMsg = fmt.Sprintf("%s %d/%d", label, i+1, len(ids))
+		}
+
+		m.bulkConfirming = false
+		m.selectedIDs = nil
+		m.reload()
+
+		if len(failed) > 0 {
+			m.statusMsg = fmt.Sprintf("Failed to %s %d of %d tasks", m.bulkAction, len(failed), len(ids))
+			cmd := tea.Tick(2*time.Second, func(time.Time) tea.Msg {
+				return struct{ clearStatus bool }{true}
+			})
+			return m, cmd
+		}
+		return m, m.startBlink(ids, false)
+
+	case "n", "N", "esc":
+		m.bulkConfirming = false
+		return m, nil
+	}
+	return m, nil
+}
+
 // getSelectedTaskID extracts the task ID from the selected row
 func (m *Model) getSelectedTaskID() (int, error) {
 	row := m.tbl.SelectedRow()
@@ -545,9 +1191,22 @@ func (m *Model) handleTaskDetailMode(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if m.detailSearching {
 		var cmd tea.Cmd
 		switch msg.Type {
+		case tea.KeyCtrlF:
+			m.detailSearchFuzzy = !m.detailSearchFuzzy
+			if m.detailSearchFuzzy {
+				m.statusMsg = "Fuzzy search"
+			} else {
+				m.statusMsg = "Regex search"
+			}
+			return m, nil
 		case tea.KeyEnter:
 			pattern := m.detailSearchInput.Value()
-			if pattern != "" {
+			m.detailSearchMatches = nil
+			if pattern != "" && m.detailSearchFuzzy {
+				m.detailSearchRegex = nil
+				fp := compileAndCacheFuzzy(pattern)
+				m.detailSearchMatches = fuzzyMatchLines(fp, m.getDetailLines())
+			} else if pattern != "" {
 				re, err := compileAndCacheRegex(pattern)
 				if err == nil {
 					m.detailSearchRegex = re